@@ -0,0 +1,45 @@
+package goenv
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FS abstracts the filesystem operations goenv needs to discover and read
+// .env files. The default implementation, osFS, operates on the local disk;
+// callers can supply their own FS to drive the search over an in-memory
+// tree, a testing/fstest.MapFS, an embed.FS, or a chrooted subtree. See
+// FromFS for adapting a standard library fs.FS.
+type FS interface {
+	ReadDir(name string) ([]os.DirEntry, error)
+	Stat(name string) (os.FileInfo, error)
+	Open(name string) (io.ReadCloser, error)
+	Abs(path string) (string, error)
+}
+
+// osFS is the default FS, backed by the local disk via the os package.
+type osFS struct{}
+
+func (osFS) ReadDir(name string) ([]os.DirEntry, error) { return os.ReadDir(name) }
+func (osFS) Stat(name string) (os.FileInfo, error)      { return os.Stat(name) }
+func (osFS) Open(name string) (io.ReadCloser, error)    { return os.Open(name) }
+func (osFS) Abs(path string) (string, error)            { return filepath.Abs(path) }
+
+// FromFS adapts a standard library fs.FS, such as a testing/fstest.MapFS or
+// an embed.FS, into an FS. Since those filesystems have no notion of a
+// current working directory, Abs merely cleans the path instead of
+// resolving it against one.
+func FromFS(fsys fs.FS) FS {
+	return fsAdapter{fsys}
+}
+
+type fsAdapter struct {
+	fsys fs.FS
+}
+
+func (a fsAdapter) ReadDir(name string) ([]os.DirEntry, error) { return fs.ReadDir(a.fsys, name) }
+func (a fsAdapter) Stat(name string) (os.FileInfo, error)      { return fs.Stat(a.fsys, name) }
+func (a fsAdapter) Open(name string) (io.ReadCloser, error)    { return a.fsys.Open(name) }
+func (a fsAdapter) Abs(path string) (string, error)            { return filepath.Clean(path), nil }
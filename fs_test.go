@@ -0,0 +1,57 @@
+package goenv
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+)
+
+// Test_LoadWithFS tests loading from an in-memory filesystem, without touching the local disk.
+func Test_LoadWithFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"folder1/folder2/.env": &fstest.MapFile{Data: []byte("FS_VAR=fs_value")},
+	}
+
+	os.Unsetenv("FS_VAR")
+	defer os.Unsetenv("FS_VAR")
+
+	config := &Config{
+		EnvFiles:    []string{".env"},
+		MaxDepth:    MaxDepth,
+		StopOnFirst: true,
+		ExpandVars:  true,
+	}
+
+	if err := LoadWithFS(FromFS(fsys), config); err != nil {
+		t.Fatalf("LoadWithFS failed: %v", err)
+	}
+
+	if val := os.Getenv("FS_VAR"); val != "fs_value" {
+		t.Errorf("Expected FS_VAR=fs_value, got %s", val)
+	}
+}
+
+// Test_FromFS_RespectsMaxDepth tests that depth limiting still applies when driven by an fs.FS.
+func Test_FromFS_RespectsMaxDepth(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a/b/c/d/.env": &fstest.MapFile{Data: []byte("DEEP_FS_VAR=deep")},
+	}
+
+	os.Unsetenv("DEEP_FS_VAR")
+	defer os.Unsetenv("DEEP_FS_VAR")
+
+	config := &Config{
+		EnvFiles:    []string{".env"},
+		MaxDepth:    1,
+		StopOnFirst: true,
+		ExpandVars:  true,
+	}
+
+	if err := LoadWithFS(FromFS(fsys), config); err != nil {
+		t.Fatalf("LoadWithFS failed: %v", err)
+	}
+
+	if val := os.Getenv("DEEP_FS_VAR"); val != "" {
+		t.Errorf("Expected DEEP_FS_VAR to be empty due to depth limit, got %s", val)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -14,29 +16,113 @@ const (
 	MaxDepth       = 10 // Prevent infinite recursion
 )
 
+// SearchMode selects the direction loadFromDirectory/loadUpward walk the
+// filesystem tree when looking for .env files.
+type SearchMode int
+
+const (
+	// SearchDown recursively scans CWD and its subdirectories (the historical behavior).
+	SearchDown SearchMode = iota
+	// SearchUp walks from CWD toward the filesystem root.
+	SearchUp
+	// SearchBoth walks upward first, then runs a downward scan as well,
+	// unless StopOnFirst is set and the upward walk already loaded something.
+	SearchBoth
+)
+
 type Config struct {
 	EnvFiles    []string
 	MaxDepth    int
 	StopOnFirst bool
 	Prefix      string
+	// ExpandVars enables POSIX-style variable interpolation (${VAR}, $VAR,
+	// ${VAR:-default}) inside unquoted and double-quoted values. Disable it
+	// to keep the historical behavior of treating values literally.
+	ExpandVars bool
+	// Setter is called for every key/value pair discovered during loading,
+	// defaulting to os.Setenv. Supplying a custom Setter lets callers capture
+	// values into a sync.Map, feed a viper-style store, or apply them some
+	// other way instead of mutating the process environment.
+	Setter func(key, value string) error
+	// Overload controls whether a variable that is already set in the
+	// process environment gets overwritten. DefaultConfig sets it to true,
+	// matching the historical behavior of os.Setenv always overriding; the
+	// zero value false leaves existing variables untouched.
+	Overload bool
+	// SearchMode selects whether the search walks down from CWD (the
+	// default), up toward the filesystem root, or both.
+	SearchMode SearchMode
+	// RootMarkers stops an upward search once a directory containing one of
+	// these entries (e.g. "go.mod", ".git") has been checked.
+	RootMarkers []string
+	// IncludePatterns force-include directories matching any of these
+	// doublestar-style globs even if an exclude pattern would otherwise
+	// prune them. Evaluated during the downward walk in loadFromDirectory;
+	// a directory below an excluded ancestor is not reached unless that
+	// ancestor is included as well.
+	IncludePatterns []string
+	// ExcludePatterns prune directories matching any of these
+	// doublestar-style globs (e.g. "**/vendor/**", "node_modules/**") from
+	// the downward walk. A .envignore file at the search root, if present,
+	// is honored alongside these using the same ignore-pattern semantics.
+	ExcludePatterns []string
+	// WatchDebounce coalesces bursts of filesystem events (e.g. an editor's
+	// save storm) before Watch re-parses and reports a change. Defaults to
+	// 200ms.
+	WatchDebounce time.Duration
 }
 
 func DefaultConfig() *Config {
 	return &Config{
-		EnvFiles:    []string{".env"},
-		MaxDepth:    MaxDepth,
-		StopOnFirst: true,
-		Prefix:      "",
+		EnvFiles:      []string{".env"},
+		MaxDepth:      MaxDepth,
+		StopOnFirst:   true,
+		Prefix:        "",
+		ExpandVars:    true,
+		Setter:        os.Setenv,
+		Overload:      true,
+		SearchMode:    SearchDown,
+		WatchDebounce: 200 * time.Millisecond,
 	}
 }
 
+// lastLoaded tracks the variables set by the most recent Load, LoadWithConfig,
+// LoadWithFS or LoadFile call, so that Unload can undo it without requiring
+// the caller to remember which keys were loaded.
+var (
+	lastLoadedMu sync.Mutex
+	lastLoaded   map[string]string
+)
+
+func recordLastLoaded(loaded map[string]string) {
+	lastLoadedMu.Lock()
+	defer lastLoadedMu.Unlock()
+	lastLoaded = loaded
+}
+
 // LoadWithConfig loads environment variables with custom configuration
 func LoadWithConfig(config *Config) error {
+	return LoadWithFS(osFS{}, config)
+}
+
+// LoadWithFS loads environment variables with custom configuration, searching
+// and reading files through fsys instead of the local disk directly. This
+// allows the search to be driven by an in-memory tree, a
+// testing/fstest.MapFS, an embed.FS (see FromFS), or a chrooted subtree.
+func LoadWithFS(fsys FS, config *Config) error {
+	if fsys == nil {
+		fsys = osFS{}
+	}
 	if config == nil {
 		config = DefaultConfig()
 	}
 
-	return loadFromDirectory(".", config, 0, make(map[string]bool))
+	loaded := make(map[string]string)
+	if err := search(fsys, config, loaded); err != nil {
+		return err
+	}
+	recordLastLoaded(loaded)
+	return nil
 }
 
 // Load provides backward compatibility with default behavior
@@ -44,14 +130,126 @@ func Load() error {
 	return LoadWithConfig(nil)
 }
 
+// Parse reads and parses the .env file at path, like LoadFile, but returns
+// its variables as a map instead of mutating the process environment.
+func Parse(path string) (map[string]string, error) {
+	result := make(map[string]string)
+	if err := loadVarsFromFile(osFS{}, path, captureConfig(DefaultConfig(), result), result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ParseWithConfig performs the same discovery as LoadWithConfig, but returns
+// the discovered variables as a map instead of mutating the process
+// environment.
+func ParseWithConfig(config *Config) (map[string]string, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	result := make(map[string]string)
+	if err := search(osFS{}, captureConfig(config, result), result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// search dispatches to the downward and/or upward walk according to config.SearchMode.
+func search(fsys FS, config *Config, loaded map[string]string) error {
+	switch config.SearchMode {
+	case SearchUp:
+		return loadUpward(fsys, config, loaded)
+	case SearchBoth:
+		if err := loadUpward(fsys, config, loaded); err != nil {
+			return err
+		}
+		if config.StopOnFirst && len(loaded) > 0 {
+			return nil
+		}
+		return downwardSearch(fsys, config, loaded)
+	default:
+		return downwardSearch(fsys, config, loaded)
+	}
+}
+
+// downwardSearch compiles the ignore rules for the current config and runs
+// the recursive downward walk from the search root.
+func downwardSearch(fsys FS, config *Config, loaded map[string]string) error {
+	matcher, err := buildIgnoreMatcher(fsys, config)
+	if err != nil {
+		return fmt.Errorf("failed to build ignore rules: %w", err)
+	}
+	return loadFromDirectory(fsys, ".", config, 0, make(map[string]bool), loaded, matcher)
+}
+
+// loadUpward walks from the current directory toward the filesystem root
+// looking for config.EnvFiles, stopping once a directory containing one of
+// config.RootMarkers has been checked, or the directory MaxDepth levels
+// above the current one has been checked.
+func loadUpward(fsys FS, config *Config, loaded map[string]string) error {
+	dir, err := fsys.Abs(".")
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path for .: %w", err)
+	}
+
+	for depth := 0; depth <= config.MaxDepth; depth++ {
+		for _, envFile := range config.EnvFiles {
+			envPath := filepath.Join(dir, envFile)
+			if fileExists(fsys, envPath) {
+				if err := loadVarsFromFile(fsys, envPath, config, loaded); err != nil {
+					return fmt.Errorf("failed to load %s: %w", envPath, err)
+				}
+				if config.StopOnFirst {
+					return nil
+				}
+			}
+		}
+
+		if hasRootMarker(fsys, dir, config.RootMarkers) {
+			return nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil // reached the filesystem root
+		}
+		dir = parent
+	}
+
+	return nil
+}
+
+// hasRootMarker reports whether dir contains any of the given marker entries.
+func hasRootMarker(fsys FS, dir string, markers []string) bool {
+	for _, marker := range markers {
+		if _, err := fsys.Stat(filepath.Join(dir, marker)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// captureConfig returns a shallow copy of config whose Setter captures
+// values into dst instead of applying them, so Parse/ParseWithConfig can
+// reuse the regular loading pipeline without touching os.Environ.
+func captureConfig(config *Config, dst map[string]string) *Config {
+	clone := *config
+	clone.Setter = func(key, value string) error {
+		dst[key] = value
+		return nil
+	}
+	return &clone
+}
+
 // loadFromDirectory recursively searches for .env files with proper error handling and cycle detection
-func loadFromDirectory(dir string, config *Config, depth int, visited map[string]bool) error {
+func loadFromDirectory(fsys FS, dir string, config *Config, depth int, visited map[string]bool, loaded map[string]string, matcher *ignoreMatcher) error {
 	if depth > config.MaxDepth {
 		return nil
 	}
 
 	// Get absolute path to detect cycles
-	absPath, err := filepath.Abs(dir)
+	absPath, err := fsys.Abs(dir)
 	if err != nil {
 		return fmt.Errorf("failed to get absolute path for %s: %w", dir, err)
 	}
@@ -64,8 +262,8 @@ func loadFromDirectory(dir string, config *Config, depth int, visited map[string
 	// Check for .env files in current directory
 	for _, envFile := range config.EnvFiles {
 		envPath := filepath.Join(dir, envFile)
-		if fileExists(envPath) {
-			if err := loadVarsFromFile(envPath); err != nil {
+		if fileExists(fsys, envPath) {
+			if err := loadVarsFromFile(fsys, envPath, config, loaded); err != nil {
 				return fmt.Errorf("failed to load %s: %w", envPath, err)
 			}
 			if config.StopOnFirst {
@@ -75,7 +273,7 @@ func loadFromDirectory(dir string, config *Config, depth int, visited map[string
 	}
 
 	// Read directory entries
-	entries, err := os.ReadDir(dir)
+	entries, err := fsys.ReadDir(dir)
 	if err != nil {
 		return fmt.Errorf("failed to read directory %s: %w", dir, err)
 	}
@@ -84,7 +282,10 @@ func loadFromDirectory(dir string, config *Config, depth int, visited map[string
 	for _, entry := range entries {
 		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
 			subDir := filepath.Join(dir, entry.Name())
-			if err := loadFromDirectory(subDir, config, depth+1, visited); err != nil {
+			if matcher.excluded(subDir, true) {
+				continue
+			}
+			if err := loadFromDirectory(fsys, subDir, config, depth+1, visited, loaded, matcher); err != nil {
 				return err
 			}
 		}
@@ -94,64 +295,257 @@ func loadFromDirectory(dir string, config *Config, depth int, visited map[string
 }
 
 // fileExists checks if a file exists and is not a directory
-func fileExists(path string) bool {
-	info, err := os.Stat(path)
+func fileExists(fsys FS, path string) bool {
+	info, err := fsys.Stat(path)
 	return err == nil && !info.IsDir()
 }
 
 // loadVarsFromFile parses an .env file with improved error handling and format support
-func loadVarsFromFile(path string) error {
-	file, err := os.Open(path)
+func loadVarsFromFile(fsys FS, path string, config *Config, loaded map[string]string) error {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	if loaded == nil {
+		loaded = make(map[string]string)
+	}
+
+	file, err := fsys.Open(path)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
+	var lines []string
 	scanner := bufio.NewScanner(file)
-	lineNum := 0
-
 	for scanner.Scan() {
-		lineNum++
-		line := strings.TrimSpace(scanner.Text())
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(lines); {
+		line := strings.TrimSpace(lines[i])
 
 		// Skip empty lines and comments
 		if line == "" || strings.HasPrefix(line, "#") {
+			i++
 			continue
 		}
 
-		// Parse key=value pairs
-		if err := parseAndSetEnvVar(line, path, lineNum); err != nil {
+		next, err := parseAndSetEnvVar(lines, i, path, config, loaded)
+		if err != nil {
 			return err
 		}
+		i = next
 	}
 
-	return scanner.Err()
+	return nil
 }
 
-// parseAndSetEnvVar parses a single environment variable line
-func parseAndSetEnvVar(line, filePath string, lineNum int) error {
+// parseAndSetEnvVar parses the key=value pair starting at lines[idx], consuming
+// further lines when the value is a multi-line quoted string, and returns the
+// index of the next line to process.
+func parseAndSetEnvVar(lines []string, idx int, filePath string, config *Config, loaded map[string]string) (int, error) {
+	lineNum := idx + 1
+	line := strings.TrimSpace(lines[idx])
+
 	parts := strings.SplitN(line, "=", 2)
 	if len(parts) != 2 {
-		return fmt.Errorf("invalid format in %s at line %d: %s", filePath, lineNum, line)
+		return 0, fmt.Errorf("invalid format in %s at line %d: %s", filePath, lineNum, line)
 	}
 
 	key := strings.TrimSpace(parts[0])
-	value := strings.TrimSpace(parts[1])
+	if !isValidEnvKey(key) {
+		return 0, fmt.Errorf("invalid environment variable name in %s at line %d: %s", filePath, lineNum, key)
+	}
+
+	rawValue := strings.TrimSpace(parts[1])
+
+	value, quote, nextIdx, err := readValue(lines, idx, rawValue, filePath)
+	if err != nil {
+		return 0, err
+	}
 
-	// Handle quoted values
-	if len(value) >= 2 {
-		if (strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"")) ||
-			(strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'")) {
-			value = value[1 : len(value)-1]
+	switch quote {
+	case '\'':
+		// Single-quoted values are literal: no escapes, no expansion.
+	case '"':
+		value = unescapeDoubleQuoted(value)
+		if config.ExpandVars {
+			value = expandValue(value, loaded)
+		}
+	default:
+		if config.ExpandVars {
+			value = expandValue(value, loaded)
 		}
 	}
 
-	// Validate key format
-	if !isValidEnvKey(key) {
-		return fmt.Errorf("invalid environment variable name in %s at line %d: %s", filePath, lineNum, key)
+	if !config.Overload {
+		if _, exists := os.LookupEnv(key); exists {
+			return nextIdx, nil
+		}
+	}
+
+	setter := config.Setter
+	if setter == nil {
+		setter = os.Setenv
+	}
+	if err := setter(key, value); err != nil {
+		return 0, err
+	}
+
+	loaded[key] = value
+
+	return nextIdx, nil
+}
+
+// readValue extracts the value starting at lines[idx]. If rawValue opens a
+// quote (' or ") that is not closed on the same line, subsequent lines are
+// consumed until the matching unescaped quote is found, allowing values to
+// span multiple lines. It returns the raw (still escaped) value, the quote
+// character used (0 for unquoted), and the index of the next unprocessed line.
+func readValue(lines []string, idx int, rawValue, filePath string) (string, byte, int, error) {
+	if len(rawValue) == 0 {
+		return "", 0, idx + 1, nil
+	}
+
+	quote := rawValue[0]
+	if quote != '"' && quote != '\'' {
+		return rawValue, 0, idx + 1, nil
+	}
+
+	var b strings.Builder
+	content := rawValue[1:]
+	cursor := idx
+
+	for {
+		closeAt := findUnescapedQuote(content, quote)
+		if closeAt >= 0 {
+			b.WriteString(content[:closeAt])
+			return b.String(), quote, cursor + 1, nil
+		}
+
+		b.WriteString(content)
+
+		cursor++
+		if cursor >= len(lines) {
+			return "", 0, 0, fmt.Errorf("unterminated %c-quoted value in %s starting at line %d", quote, filePath, idx+1)
+		}
+		b.WriteString("\n")
+		content = lines[cursor]
+	}
+}
+
+// findUnescapedQuote returns the index of the first occurrence of quote in s
+// that is not preceded by an odd number of backslashes, or -1 if not found.
+func findUnescapedQuote(s string, quote byte) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] != quote {
+			continue
+		}
+		backslashes := 0
+		for j := i - 1; j >= 0 && s[j] == '\\'; j-- {
+			backslashes++
+		}
+		if backslashes%2 == 0 {
+			return i
+		}
 	}
+	return -1
+}
 
-	return os.Setenv(key, value)
+// unescapeDoubleQuoted resolves the escape sequences supported inside
+// double-quoted values: \n, \t, \\ and \".
+func unescapeDoubleQuoted(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			switch value[i+1] {
+			case 'n':
+				b.WriteByte('\n')
+				i++
+				continue
+			case 't':
+				b.WriteByte('\t')
+				i++
+				continue
+			case '\\':
+				b.WriteByte('\\')
+				i++
+				continue
+			case '"':
+				b.WriteByte('"')
+				i++
+				continue
+			}
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String()
+}
+
+// expandValue resolves $VAR, ${VAR} and ${VAR:-default} references in value
+// against the vars already loaded earlier in the current load and, failing that,
+// the process environment. Following POSIX shell semantics, ${VAR:-default}
+// falls back to default both when VAR is unset and when it is set to the
+// empty string.
+func expandValue(value string, loaded map[string]string) string {
+	lookup := func(name string) (string, bool) {
+		if v, ok := loaded[name]; ok {
+			return v, v != ""
+		}
+		v, ok := os.LookupEnv(name)
+		return v, ok && v != ""
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '$' || i+1 >= len(value) {
+			b.WriteByte(value[i])
+			continue
+		}
+
+		if value[i+1] == '{' {
+			end := strings.IndexByte(value[i+2:], '}')
+			if end < 0 {
+				b.WriteByte(value[i])
+				continue
+			}
+			expr := value[i+2 : i+2+end]
+			name, def, hasDefault := strings.Cut(expr, ":-")
+			v, ok := lookup(name)
+			if !ok && hasDefault {
+				v = def
+			}
+			b.WriteString(v)
+			i += 2 + end
+			continue
+		}
+
+		j := i + 1
+		for j < len(value) && isValidEnvKeyByte(value[j], j == i+1) {
+			j++
+		}
+		if j == i+1 {
+			b.WriteByte(value[i])
+			continue
+		}
+		name := value[i+1 : j]
+		v, _ := lookup(name)
+		b.WriteString(v)
+		i = j - 1
+	}
+	return b.String()
+}
+
+// isValidEnvKeyByte reports whether r is a valid character for an
+// unbraced $VAR reference at the given position (first vs. subsequent byte).
+func isValidEnvKeyByte(r byte, first bool) bool {
+	if first {
+		return (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || r == '_'
+	}
+	return (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_'
 }
 
 // isValidEnvKey validates environment variable key format
@@ -236,12 +630,28 @@ func MustGetString(key string) string {
 
 // LoadFile loads a specific .env file
 func LoadFile(path string) error {
-	return loadVarsFromFile(path)
+	loaded := make(map[string]string)
+	if err := loadVarsFromFile(osFS{}, path, DefaultConfig(), loaded); err != nil {
+		return err
+	}
+	recordLastLoaded(loaded)
+	return nil
 }
 
-// Unload removes all environment variables loaded from .env files
-// Note: This is a simplified implementation - tracking loaded vars would be better
-func Unload(keys []string) error {
+// Unload removes the given environment variables. If no keys are given, it
+// removes every variable set by the most recent Load, LoadWithConfig,
+// LoadWithFS or LoadFile call; values those variables held before that call
+// are not restored.
+func Unload(keys ...string) error {
+	if len(keys) == 0 {
+		lastLoadedMu.Lock()
+		for key := range lastLoaded {
+			keys = append(keys, key)
+		}
+		lastLoaded = nil
+		lastLoadedMu.Unlock()
+	}
+
 	for _, key := range keys {
 		if err := os.Unsetenv(key); err != nil {
 			return err
@@ -460,3 +460,293 @@ INVALID-DASH=invalid_dash
 		t.Errorf("Expected 'invalid environment variable name' error, got: %v", err)
 	}
 }
+
+// Test_ExpandVars tests variable interpolation in unquoted and double-quoted values.
+func Test_ExpandVars(t *testing.T) {
+	baseDir, cleanup := setupTempDir(t)
+	defer cleanup()
+
+	os.Setenv("EXPAND_FROM_ENV", "from_env")
+	defer os.Unsetenv("EXPAND_FROM_ENV")
+
+	envContent := `EXPAND_BASE=base
+EXPAND_BRACED=${EXPAND_BASE}_suffix
+EXPAND_UNBRACED=$EXPAND_BASE-suffix
+EXPAND_FROM_PROCESS=$EXPAND_FROM_ENV
+EXPAND_DEFAULT=${EXPAND_MISSING:-fallback}
+EXPAND_LITERAL='$EXPAND_BASE'`
+
+	envPath := createEnvFile(t, baseDir, ".env", envContent)
+
+	vars := []string{"EXPAND_BASE", "EXPAND_BRACED", "EXPAND_UNBRACED", "EXPAND_FROM_PROCESS", "EXPAND_DEFAULT", "EXPAND_LITERAL"}
+	for _, v := range vars {
+		os.Unsetenv(v)
+	}
+
+	if err := LoadFile(envPath); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"EXPAND_BRACED", "base_suffix"},
+		{"EXPAND_UNBRACED", "base-suffix"},
+		{"EXPAND_FROM_PROCESS", "from_env"},
+		{"EXPAND_DEFAULT", "fallback"},
+		{"EXPAND_LITERAL", "$EXPAND_BASE"},
+	}
+
+	for _, test := range tests {
+		if val := os.Getenv(test.key); val != test.expected {
+			t.Errorf("Expected %s=%q, got %q", test.key, test.expected, val)
+		}
+	}
+}
+
+// Test_EscapedCharactersInDoubleQuotes tests escape sequence handling.
+func Test_EscapedCharactersInDoubleQuotes(t *testing.T) {
+	baseDir, cleanup := setupTempDir(t)
+	defer cleanup()
+
+	envContent := `ESCAPED="line1\nline2\ttabbed\\backslash\"quoted\""`
+	envPath := createEnvFile(t, baseDir, ".env", envContent)
+
+	os.Unsetenv("ESCAPED")
+
+	if err := LoadFile(envPath); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+
+	expected := "line1\nline2\ttabbed\\backslash\"quoted\""
+	if val := os.Getenv("ESCAPED"); val != expected {
+		t.Errorf("Expected ESCAPED=%q, got %q", expected, val)
+	}
+}
+
+// Test_MultilineQuotedValue tests values spanning multiple lines inside matching quotes.
+func Test_MultilineQuotedValue(t *testing.T) {
+	baseDir, cleanup := setupTempDir(t)
+	defer cleanup()
+
+	envContent := "MULTILINE=\"first line\nsecond line\"\nAFTER=after_value"
+	envPath := createEnvFile(t, baseDir, ".env", envContent)
+
+	os.Unsetenv("MULTILINE")
+	os.Unsetenv("AFTER")
+
+	if err := LoadFile(envPath); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+
+	if val := os.Getenv("MULTILINE"); val != "first line\nsecond line" {
+		t.Errorf("Expected multiline value, got %q", val)
+	}
+
+	if val := os.Getenv("AFTER"); val != "after_value" {
+		t.Errorf("Expected AFTER=after_value, got %q", val)
+	}
+}
+
+// Test_Parse tests that Parse returns the discovered variables without touching os.Environ.
+func Test_Parse(t *testing.T) {
+	baseDir, cleanup := setupTempDir(t)
+	defer cleanup()
+
+	envPath := createEnvFile(t, baseDir, ".env", "PARSE_VAR=parse_value")
+
+	os.Unsetenv("PARSE_VAR")
+
+	result, err := Parse(envPath)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if result["PARSE_VAR"] != "parse_value" {
+		t.Errorf("Expected result[PARSE_VAR]=parse_value, got %q", result["PARSE_VAR"])
+	}
+
+	if val := os.Getenv("PARSE_VAR"); val != "" {
+		t.Errorf("Expected Parse not to set PARSE_VAR in os.Environ, got %q", val)
+	}
+}
+
+// Test_ParseWithConfig tests the configuration-based dry-run loading.
+func Test_ParseWithConfig(t *testing.T) {
+	baseDir, cleanup := setupTempDir(t)
+	defer cleanup()
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(baseDir)
+
+	createEnvFile(t, baseDir, ".env", "PARSE_CONFIG_VAR=parse_config_value")
+
+	os.Unsetenv("PARSE_CONFIG_VAR")
+
+	result, err := ParseWithConfig(DefaultConfig())
+	if err != nil {
+		t.Fatalf("ParseWithConfig failed: %v", err)
+	}
+
+	if result["PARSE_CONFIG_VAR"] != "parse_config_value" {
+		t.Errorf("Expected result[PARSE_CONFIG_VAR]=parse_config_value, got %q", result["PARSE_CONFIG_VAR"])
+	}
+
+	if val := os.Getenv("PARSE_CONFIG_VAR"); val != "" {
+		t.Errorf("Expected ParseWithConfig not to set PARSE_CONFIG_VAR in os.Environ, got %q", val)
+	}
+}
+
+// Test_CustomSetter tests that Config.Setter is used instead of os.Setenv.
+func Test_CustomSetter(t *testing.T) {
+	baseDir, cleanup := setupTempDir(t)
+	defer cleanup()
+
+	envPath := createEnvFile(t, baseDir, "custom.env", "CAPTURED_VAR=captured_value")
+
+	captured := make(map[string]string)
+	config := DefaultConfig()
+	config.Setter = func(key, value string) error {
+		captured[key] = value
+		return nil
+	}
+
+	os.Unsetenv("CAPTURED_VAR")
+
+	if err := loadVarsFromFile(osFS{}, envPath, config, make(map[string]string)); err != nil {
+		t.Fatalf("loadVarsFromFile failed: %v", err)
+	}
+
+	if captured["CAPTURED_VAR"] != "captured_value" {
+		t.Errorf("Expected captured[CAPTURED_VAR]=captured_value, got %q", captured["CAPTURED_VAR"])
+	}
+
+	if val := os.Getenv("CAPTURED_VAR"); val != "" {
+		t.Errorf("Expected CAPTURED_VAR not to be set in os.Environ, got %q", val)
+	}
+}
+
+// Test_Overload tests that Config.Overload controls whether existing vars are overwritten.
+func Test_Overload(t *testing.T) {
+	baseDir, cleanup := setupTempDir(t)
+	defer cleanup()
+
+	envPath := createEnvFile(t, baseDir, "overload.env", "OVERLOAD_VAR=new_value")
+
+	os.Setenv("OVERLOAD_VAR", "existing_value")
+	defer os.Unsetenv("OVERLOAD_VAR")
+
+	config := DefaultConfig()
+	config.Overload = false
+
+	if err := loadVarsFromFile(osFS{}, envPath, config, make(map[string]string)); err != nil {
+		t.Fatalf("loadVarsFromFile failed: %v", err)
+	}
+
+	if val := os.Getenv("OVERLOAD_VAR"); val != "existing_value" {
+		t.Errorf("Expected OVERLOAD_VAR to keep existing_value with Overload=false, got %q", val)
+	}
+
+	config.Overload = true
+	if err := loadVarsFromFile(osFS{}, envPath, config, make(map[string]string)); err != nil {
+		t.Fatalf("loadVarsFromFile failed: %v", err)
+	}
+
+	if val := os.Getenv("OVERLOAD_VAR"); val != "new_value" {
+		t.Errorf("Expected OVERLOAD_VAR to be overwritten with Overload=true, got %q", val)
+	}
+}
+
+// Test_UnloadLastLoad tests that Unload with no arguments undoes the most recent load.
+func Test_UnloadLastLoad(t *testing.T) {
+	baseDir, cleanup := setupTempDir(t)
+	defer cleanup()
+
+	envPath := createEnvFile(t, baseDir, "unload.env", "UNLOAD_VAR=unload_value")
+
+	os.Unsetenv("UNLOAD_VAR")
+
+	if err := LoadFile(envPath); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+
+	if val := os.Getenv("UNLOAD_VAR"); val != "unload_value" {
+		t.Fatalf("Expected UNLOAD_VAR=unload_value, got %q", val)
+	}
+
+	if err := Unload(); err != nil {
+		t.Fatalf("Unload failed: %v", err)
+	}
+
+	if val := os.Getenv("UNLOAD_VAR"); val != "" {
+		t.Errorf("Expected UNLOAD_VAR to be unset after Unload(), got %q", val)
+	}
+}
+
+// Test_SearchUp tests walking from a nested directory up to a parent .env file.
+func Test_SearchUp(t *testing.T) {
+	baseDir, cleanup := setupTempDir(t)
+	defer cleanup()
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	nestedDir := filepath.Join(baseDir, "a", "b", "c")
+	if err := os.MkdirAll(nestedDir, 0755); err != nil {
+		t.Fatalf("Failed to create nested directory: %v", err)
+	}
+
+	createEnvFile(t, baseDir, ".env", "SEARCH_UP_VAR=search_up_value")
+	os.Chdir(nestedDir)
+
+	os.Unsetenv("SEARCH_UP_VAR")
+
+	config := DefaultConfig()
+	config.SearchMode = SearchUp
+
+	if err := LoadWithConfig(config); err != nil {
+		t.Fatalf("LoadWithConfig failed: %v", err)
+	}
+
+	if val := os.Getenv("SEARCH_UP_VAR"); val != "search_up_value" {
+		t.Errorf("Expected SEARCH_UP_VAR=search_up_value, got %q", val)
+	}
+}
+
+// Test_SearchUpRootMarker tests that RootMarkers stops the upward walk.
+func Test_SearchUpRootMarker(t *testing.T) {
+	baseDir, cleanup := setupTempDir(t)
+	defer cleanup()
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	// .env lives above the root marker, so it must not be found.
+	createEnvFile(t, baseDir, ".env", "BEYOND_ROOT_VAR=beyond_root_value")
+
+	projectDir := filepath.Join(baseDir, "project")
+	nestedDir := filepath.Join(projectDir, "cmd", "app")
+	if err := os.MkdirAll(nestedDir, 0755); err != nil {
+		t.Fatalf("Failed to create nested directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module example\n"), 0644); err != nil {
+		t.Fatalf("Failed to create go.mod: %v", err)
+	}
+	os.Chdir(nestedDir)
+
+	os.Unsetenv("BEYOND_ROOT_VAR")
+
+	config := DefaultConfig()
+	config.SearchMode = SearchUp
+	config.RootMarkers = []string{"go.mod"}
+
+	if err := LoadWithConfig(config); err != nil {
+		t.Fatalf("LoadWithConfig failed: %v", err)
+	}
+
+	if val := os.Getenv("BEYOND_ROOT_VAR"); val != "" {
+		t.Errorf("Expected BEYOND_ROOT_VAR to stay unset beyond the root marker, got %q", val)
+	}
+}
@@ -0,0 +1,136 @@
+package goenv
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// envIgnoreFile is the name of the optional ignore file honored at the
+// search root, analogous to .gitignore.
+const envIgnoreFile = ".envignore"
+
+// ignoreRule is a single compiled pattern from Config.ExcludePatterns,
+// Config.IncludePatterns or a .envignore file.
+type ignoreRule struct {
+	pattern string
+	negate  bool
+	dirOnly bool
+}
+
+// newIgnoreRule compiles pattern into a rule. A pattern without a "/"
+// matches at any depth, like a bare name in a .gitignore file. A trailing
+// "/" restricts the rule to directories.
+func newIgnoreRule(pattern string, negate bool) ignoreRule {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	if !strings.Contains(pattern, "/") {
+		pattern = "**/" + pattern
+	}
+	return ignoreRule{pattern: pattern, negate: negate, dirOnly: dirOnly}
+}
+
+// ignoreMatcher evaluates a path against an ordered list of include/exclude
+// rules using well-known ignore-file semantics: later rules override
+// earlier ones, and a negated rule re-includes a path excluded earlier.
+type ignoreMatcher struct {
+	rules []ignoreRule
+}
+
+// buildIgnoreMatcher compiles config.ExcludePatterns, the .envignore file at
+// the search root (if any), and config.IncludePatterns, in that order, into
+// a single ignoreMatcher. IncludePatterns are compiled as re-including
+// rules, so they can force a path back in regardless of earlier excludes.
+func buildIgnoreMatcher(fsys FS, config *Config) (*ignoreMatcher, error) {
+	var rules []ignoreRule
+
+	for _, pattern := range config.ExcludePatterns {
+		rules = append(rules, newIgnoreRule(pattern, false))
+	}
+
+	file, err := fsys.Open(filepath.Join(".", envIgnoreFile))
+	if err == nil {
+		defer file.Close()
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			negate := strings.HasPrefix(line, "!")
+			if negate {
+				line = strings.TrimPrefix(line, "!")
+			}
+			rules = append(rules, newIgnoreRule(line, negate))
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	for _, pattern := range config.IncludePatterns {
+		rules = append(rules, newIgnoreRule(pattern, true))
+	}
+
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	return &ignoreMatcher{rules: rules}, nil
+}
+
+// excluded reports whether relPath (slash-separated, relative to the search
+// root) should be pruned from the walk.
+func (m *ignoreMatcher) excluded(relPath string, isDir bool) bool {
+	if m == nil {
+		return false
+	}
+
+	relPath = filepath.ToSlash(relPath)
+	excluded := false
+	for _, rule := range m.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if matchGlob(rule.pattern, relPath) {
+			excluded = !rule.negate
+		}
+	}
+	return excluded
+}
+
+// matchGlob reports whether the slash-separated path matches the
+// doublestar-style glob pattern, where "**" matches zero or more whole path
+// segments and "*"/"?"/"[...]" match within a single segment.
+func matchGlob(pattern, path string) bool {
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(path, "/"))
+}
+
+func matchGlobSegments(patternSegs, pathSegs []string) bool {
+	if len(patternSegs) == 0 {
+		return len(pathSegs) == 0
+	}
+
+	if patternSegs[0] == "**" {
+		if len(patternSegs) == 1 {
+			return true
+		}
+		for i := 0; i <= len(pathSegs); i++ {
+			if matchGlobSegments(patternSegs[1:], pathSegs[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(pathSegs) == 0 {
+		return false
+	}
+
+	if ok, err := filepath.Match(patternSegs[0], pathSegs[0]); err != nil || !ok {
+		return false
+	}
+	return matchGlobSegments(patternSegs[1:], pathSegs[1:])
+}
@@ -0,0 +1,125 @@
+package goenv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Test_ExcludePatterns tests that ExcludePatterns prune matching directories from the walk.
+func Test_ExcludePatterns(t *testing.T) {
+	baseDir, cleanup := setupTempDir(t)
+	defer cleanup()
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(baseDir)
+
+	vendorDir := filepath.Join(baseDir, "vendor", "pkg")
+	if err := os.MkdirAll(vendorDir, 0755); err != nil {
+		t.Fatalf("Failed to create vendor directory: %v", err)
+	}
+	createEnvFile(t, vendorDir, ".env", "VENDOR_VAR=vendor_value")
+
+	os.Unsetenv("VENDOR_VAR")
+
+	config := DefaultConfig()
+	config.StopOnFirst = false
+	config.ExcludePatterns = []string{"vendor/**"}
+
+	if err := LoadWithConfig(config); err != nil {
+		t.Fatalf("LoadWithConfig failed: %v", err)
+	}
+
+	if val := os.Getenv("VENDOR_VAR"); val != "" {
+		t.Errorf("Expected VENDOR_VAR to stay unset under excluded vendor/, got %q", val)
+	}
+}
+
+// Test_IncludePatterns tests that IncludePatterns re-include a path pruned by an exclude pattern.
+func Test_IncludePatterns(t *testing.T) {
+	baseDir, cleanup := setupTempDir(t)
+	defer cleanup()
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(baseDir)
+
+	keepDir := filepath.Join(baseDir, "vendor", "keep")
+	if err := os.MkdirAll(keepDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	createEnvFile(t, keepDir, ".env", "KEEP_VAR=keep_value")
+
+	os.Unsetenv("KEEP_VAR")
+
+	config := DefaultConfig()
+	config.StopOnFirst = false
+	config.ExcludePatterns = []string{"vendor/**"}
+	// Re-including a nested path also requires re-including each excluded
+	// ancestor directory, matching well-known ignore-file semantics.
+	config.IncludePatterns = []string{"vendor", "vendor/keep"}
+
+	if err := LoadWithConfig(config); err != nil {
+		t.Fatalf("LoadWithConfig failed: %v", err)
+	}
+
+	if val := os.Getenv("KEEP_VAR"); val != "keep_value" {
+		t.Errorf("Expected KEEP_VAR=keep_value, got %q", val)
+	}
+}
+
+// Test_EnvIgnoreFile tests that a .envignore file at the search root prunes matching directories.
+func Test_EnvIgnoreFile(t *testing.T) {
+	baseDir, cleanup := setupTempDir(t)
+	defer cleanup()
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(baseDir)
+
+	nodeModulesDir := filepath.Join(baseDir, "node_modules", "some-pkg")
+	if err := os.MkdirAll(nodeModulesDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	createEnvFile(t, nodeModulesDir, ".env", "NODE_MODULES_VAR=nm_value")
+
+	if err := os.WriteFile(filepath.Join(baseDir, ".envignore"), []byte("node_modules/\n"), 0644); err != nil {
+		t.Fatalf("Failed to create .envignore: %v", err)
+	}
+
+	os.Unsetenv("NODE_MODULES_VAR")
+
+	config := DefaultConfig()
+	config.StopOnFirst = false
+
+	if err := LoadWithConfig(config); err != nil {
+		t.Fatalf("LoadWithConfig failed: %v", err)
+	}
+
+	if val := os.Getenv("NODE_MODULES_VAR"); val != "" {
+		t.Errorf("Expected NODE_MODULES_VAR to stay unset under .envignore'd node_modules/, got %q", val)
+	}
+}
+
+// Test_MatchGlob tests the doublestar-style glob matcher directly.
+func Test_MatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"**/vendor/**", "a/vendor/b/c", true},
+		{"**/vendor/**", "vendor", true},
+		{"node_modules/**", "node_modules/pkg", true},
+		{"node_modules/**", "other/node_modules/pkg", false},
+		{"*.env", "local.env", true},
+		{"*.env", "a/local.env", false},
+	}
+
+	for _, test := range tests {
+		if got := matchGlob(test.pattern, test.path); got != test.want {
+			t.Errorf("matchGlob(%q, %q) = %v; want %v", test.pattern, test.path, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package goenv
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Unmarshal populates the exported fields of v, a pointer to a struct, from
+// environment variables using `env:"NAME,required"` and `default:"value"`
+// struct tags. Supported field types are string, the signed/float integer
+// kinds, bool, time.Duration, time.Time (RFC3339), []string (separated by
+// the `sep` tag, defaulting to ","), map[string]string ("k1:v1,k2:v2"), and
+// nested structs, whose `env` tag (if any) is used as a prefix for their own
+// fields.
+func Unmarshal(v interface{}) error {
+	return UnmarshalWithConfig(v, DefaultConfig())
+}
+
+// UnmarshalWithConfig does the same as Unmarshal but honors config.Prefix as
+// a prefix applied to every environment variable name before lookup.
+func UnmarshalWithConfig(v interface{}, config *Config) error {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("goenv: Unmarshal target must be a non-nil pointer to a struct")
+	}
+
+	var errs []string
+	unmarshalStruct(rv.Elem(), config.Prefix, &errs)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("goenv: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// unmarshalStruct walks the fields of structVal, binding each to an
+// environment variable name prefixed with prefix, and appending any
+// missing-required or parse failure to errs.
+func unmarshalStruct(structVal reflect.Value, prefix string, errs *[]string) {
+	t := structVal.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldVal := structVal.Field(i)
+		if !fieldVal.CanSet() {
+			continue
+		}
+
+		tag, hasTag := field.Tag.Lookup("env")
+		var name string
+		var required bool
+		if hasTag {
+			name, required = parseEnvTag(tag)
+		}
+
+		if fieldVal.Kind() == reflect.Struct && fieldVal.Type() != timeType {
+			unmarshalStruct(fieldVal, prefix+name, errs)
+			continue
+		}
+
+		if !hasTag {
+			continue
+		}
+
+		key := prefix + name
+		raw, ok := os.LookupEnv(key)
+		if !ok {
+			if defaultValue, hasDefault := field.Tag.Lookup("default"); hasDefault {
+				raw = defaultValue
+			} else if required {
+				*errs = append(*errs, fmt.Sprintf("missing required environment variable %q for field %s", key, field.Name))
+				continue
+			} else {
+				continue
+			}
+		}
+
+		sep := field.Tag.Get("sep")
+		if sep == "" {
+			sep = ","
+		}
+
+		if err := setFieldValue(fieldVal, raw, sep); err != nil {
+			*errs = append(*errs, fmt.Sprintf("invalid value for field %s (%s): %v", field.Name, key, err))
+		}
+	}
+}
+
+// parseEnvTag splits an `env` tag into its variable name and option list,
+// recognizing the "required" option.
+func parseEnvTag(tag string) (name string, required bool) {
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	for _, opt := range parts[1:] {
+		if opt == "required" {
+			required = true
+		}
+	}
+	return name, required
+}
+
+// setFieldValue converts raw into fieldVal's type and assigns it.
+func setFieldValue(fieldVal reflect.Value, raw, sep string) error {
+	switch fieldVal.Interface().(type) {
+	case time.Duration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		fieldVal.SetInt(int64(d))
+		return nil
+	case time.Time:
+		tm, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return err
+		}
+		fieldVal.Set(reflect.ValueOf(tm))
+		return nil
+	}
+
+	switch fieldVal.Kind() {
+	case reflect.String:
+		fieldVal.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		fieldVal.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		fieldVal.SetBool(b)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		fieldVal.SetFloat(f)
+	case reflect.Slice:
+		if fieldVal.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported slice element type %s", fieldVal.Type().Elem())
+		}
+		items := strings.Split(raw, sep)
+		for i := range items {
+			items[i] = strings.TrimSpace(items[i])
+		}
+		fieldVal.Set(reflect.ValueOf(items))
+	case reflect.Map:
+		if fieldVal.Type().Key().Kind() != reflect.String || fieldVal.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map type %s", fieldVal.Type())
+		}
+		m := reflect.MakeMap(fieldVal.Type())
+		if raw != "" {
+			for _, pair := range strings.Split(raw, ",") {
+				k, v, found := strings.Cut(pair, ":")
+				if !found {
+					return fmt.Errorf("invalid map entry %q, expected key:value", pair)
+				}
+				m.SetMapIndex(reflect.ValueOf(strings.TrimSpace(k)), reflect.ValueOf(strings.TrimSpace(v)))
+			}
+		}
+		fieldVal.Set(m)
+	default:
+		return fmt.Errorf("unsupported field type %s", fieldVal.Type())
+	}
+	return nil
+}
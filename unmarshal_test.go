@@ -0,0 +1,103 @@
+package goenv
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test_Unmarshal tests populating a struct from environment variables, including defaults and nested structs.
+func Test_Unmarshal(t *testing.T) {
+	type DBConfig struct {
+		URL string `env:"URL,required"`
+	}
+
+	type AppConfig struct {
+		Port     int               `env:"PORT" default:"8080"`
+		Debug    bool              `env:"DEBUG"`
+		Timeout  time.Duration     `env:"TIMEOUT" default:"5s"`
+		Tags     []string          `env:"TAGS"`
+		Labels   map[string]string `env:"LABELS"`
+		Database DBConfig          `env:"DB_"`
+	}
+
+	vars := map[string]string{
+		"PORT":   "9090",
+		"DEBUG":  "true",
+		"TAGS":   "a,b,c",
+		"LABELS": "env:prod,region:eu",
+		"DB_URL": "postgres://localhost/app",
+	}
+	for k, v := range vars {
+		os.Setenv(k, v)
+		defer os.Unsetenv(k)
+	}
+
+	var cfg AppConfig
+	if err := Unmarshal(&cfg); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if cfg.Port != 9090 {
+		t.Errorf("Expected Port=9090, got %d", cfg.Port)
+	}
+	if !cfg.Debug {
+		t.Errorf("Expected Debug=true, got %v", cfg.Debug)
+	}
+	if cfg.Timeout != 5*time.Second {
+		t.Errorf("Expected Timeout=5s (from default), got %v", cfg.Timeout)
+	}
+	if len(cfg.Tags) != 3 || cfg.Tags[0] != "a" || cfg.Tags[2] != "c" {
+		t.Errorf("Expected Tags=[a b c], got %v", cfg.Tags)
+	}
+	if cfg.Labels["env"] != "prod" || cfg.Labels["region"] != "eu" {
+		t.Errorf("Expected Labels to contain env=prod and region=eu, got %v", cfg.Labels)
+	}
+	if cfg.Database.URL != "postgres://localhost/app" {
+		t.Errorf("Expected Database.URL=postgres://localhost/app, got %q", cfg.Database.URL)
+	}
+}
+
+// Test_Unmarshal_MissingRequired tests that missing required fields are aggregated into a single error.
+func Test_Unmarshal_MissingRequired(t *testing.T) {
+	type Config struct {
+		APIKey string `env:"MISSING_API_KEY,required"`
+		Secret string `env:"MISSING_SECRET,required"`
+	}
+
+	os.Unsetenv("MISSING_API_KEY")
+	os.Unsetenv("MISSING_SECRET")
+
+	var cfg Config
+	err := Unmarshal(&cfg)
+	if err == nil {
+		t.Fatal("Expected error for missing required fields, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "MISSING_API_KEY") || !strings.Contains(err.Error(), "MISSING_SECRET") {
+		t.Errorf("Expected error to mention both missing fields, got: %v", err)
+	}
+}
+
+// Test_UnmarshalWithConfig_Prefix tests that Config.Prefix is applied to every resolved key.
+func Test_UnmarshalWithConfig_Prefix(t *testing.T) {
+	type Config struct {
+		Host string `env:"HOST"`
+	}
+
+	os.Setenv("MYAPP_HOST", "example.com")
+	defer os.Unsetenv("MYAPP_HOST")
+
+	config := DefaultConfig()
+	config.Prefix = "MYAPP_"
+
+	var cfg Config
+	if err := UnmarshalWithConfig(&cfg, config); err != nil {
+		t.Fatalf("UnmarshalWithConfig failed: %v", err)
+	}
+
+	if cfg.Host != "example.com" {
+		t.Errorf("Expected Host=example.com, got %q", cfg.Host)
+	}
+}
@@ -0,0 +1,285 @@
+package goenv
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watch discovers the same .env files LoadWithConfig would load for config,
+// installs filesystem watches on their parent directories, and re-parses
+// them whenever one is created, modified or removed, invoking onChange with
+// the resulting diff against the previous state. Watch blocks until ctx is
+// canceled or an unrecoverable error occurs.
+func Watch(ctx context.Context, config *Config, onChange func(changed map[string]string, removed []string)) error {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	debounce := config.WatchDebounce
+	if debounce <= 0 {
+		debounce = 200 * time.Millisecond
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	files, err := discoverEnvFiles(osFS{}, config)
+	if err != nil {
+		return err
+	}
+
+	watchedDirs := make(map[string]bool)
+	for _, path := range files {
+		dir := filepath.Dir(path)
+		if watchedDirs[dir] {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+		watchedDirs[dir] = true
+	}
+
+	current, err := ParseWithConfig(config)
+	if err != nil {
+		return err
+	}
+
+	var (
+		mu      sync.Mutex
+		timer   *time.Timer
+		stopped bool
+	)
+	fatal := make(chan error, 1)
+
+	// reload holds mu for its entire body, so a slower reload can never
+	// overwrite current with stale data after a faster, more recent one has
+	// already applied, and so it can check stopped before touching current
+	// or calling onChange after Watch has returned.
+	reload := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if stopped {
+			return
+		}
+
+		next, err := ParseWithConfig(config)
+		if err != nil {
+			return
+		}
+
+		changed := make(map[string]string)
+		for key, value := range next {
+			if old, ok := current[key]; !ok || old != value {
+				changed[key] = value
+			}
+		}
+
+		var removed []string
+		for key := range current {
+			if _, ok := next[key]; !ok {
+				removed = append(removed, key)
+			}
+		}
+
+		current = next
+
+		if len(changed) > 0 || len(removed) > 0 {
+			onChange(changed, removed)
+		}
+	}
+
+	stop := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		stopped = true
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			stop()
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				stop()
+				return nil
+			}
+			if !isEnvFileEvent(event.Name, config.EnvFiles) {
+				continue
+			}
+
+			// Editors frequently save by removing and recreating a file,
+			// which some platforms surface as the watch itself going away;
+			// re-adding the parent directory keeps future events flowing.
+			if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+				dir := filepath.Dir(event.Name)
+				if err := watcher.Add(dir); err != nil {
+					fatal <- fmt.Errorf("failed to re-watch %s: %w", dir, err)
+					continue
+				}
+			}
+
+			mu.Lock()
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(debounce, reload)
+			mu.Unlock()
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				stop()
+				return nil
+			}
+			stop()
+			return err
+		case err := <-fatal:
+			stop()
+			return err
+		}
+	}
+}
+
+// isEnvFileEvent reports whether name's base matches one of envFiles.
+func isEnvFileEvent(name string, envFiles []string) bool {
+	base := filepath.Base(name)
+	for _, envFile := range envFiles {
+		if base == envFile {
+			return true
+		}
+	}
+	return false
+}
+
+// discoverEnvFiles returns the absolute paths of every .env file
+// LoadWithConfig would load for config, without reading or parsing them.
+func discoverEnvFiles(fsys FS, config *Config) ([]string, error) {
+	var files []string
+	collect := func(path string) { files = append(files, path) }
+
+	switch config.SearchMode {
+	case SearchUp:
+		if err := discoverUpward(fsys, config, collect); err != nil {
+			return nil, err
+		}
+	case SearchBoth:
+		if err := discoverUpward(fsys, config, collect); err != nil {
+			return nil, err
+		}
+		if config.StopOnFirst && len(files) > 0 {
+			break
+		}
+		if err := discoverDown(fsys, config, collect); err != nil {
+			return nil, err
+		}
+	default:
+		if err := discoverDown(fsys, config, collect); err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+// discoverUpward mirrors loadUpward's traversal, but only collects matching
+// file paths instead of parsing them.
+func discoverUpward(fsys FS, config *Config, collect func(string)) error {
+	dir, err := fsys.Abs(".")
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path for .: %w", err)
+	}
+
+	for depth := 0; depth <= config.MaxDepth; depth++ {
+		for _, envFile := range config.EnvFiles {
+			envPath := filepath.Join(dir, envFile)
+			if fileExists(fsys, envPath) {
+				collect(envPath)
+				if config.StopOnFirst {
+					return nil
+				}
+			}
+		}
+
+		if hasRootMarker(fsys, dir, config.RootMarkers) {
+			return nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+	}
+
+	return nil
+}
+
+// discoverDown mirrors the downward walk in loadFromDirectory, but only
+// collects matching file paths instead of parsing them.
+func discoverDown(fsys FS, config *Config, collect func(string)) error {
+	matcher, err := buildIgnoreMatcher(fsys, config)
+	if err != nil {
+		return fmt.Errorf("failed to build ignore rules: %w", err)
+	}
+	return discoverDirectory(fsys, ".", config, 0, make(map[string]bool), matcher, collect)
+}
+
+func discoverDirectory(fsys FS, dir string, config *Config, depth int, visited map[string]bool, matcher *ignoreMatcher, collect func(string)) error {
+	if depth > config.MaxDepth {
+		return nil
+	}
+
+	absPath, err := fsys.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path for %s: %w", dir, err)
+	}
+	if visited[absPath] {
+		return nil
+	}
+	visited[absPath] = true
+
+	for _, envFile := range config.EnvFiles {
+		envPath := filepath.Join(dir, envFile)
+		if fileExists(fsys, envPath) {
+			abs, err := fsys.Abs(envPath)
+			if err != nil {
+				return fmt.Errorf("failed to get absolute path for %s: %w", envPath, err)
+			}
+			collect(abs)
+			if config.StopOnFirst {
+				return nil
+			}
+		}
+	}
+
+	entries, err := fsys.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			subDir := filepath.Join(dir, entry.Name())
+			if matcher.excluded(subDir, true) {
+				continue
+			}
+			if err := discoverDirectory(fsys, subDir, config, depth+1, visited, matcher, collect); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package goenv
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+// Test_Watch tests that Watch reports a changed variable after the .env file is edited.
+func Test_Watch(t *testing.T) {
+	baseDir, cleanup := setupTempDir(t)
+	defer cleanup()
+
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(baseDir)
+
+	envPath := createEnvFile(t, baseDir, ".env", "WATCH_VAR=initial")
+
+	config := DefaultConfig()
+	config.WatchDebounce = 20 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	type diff struct {
+		changed map[string]string
+		removed []string
+	}
+	results := make(chan diff, 4)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Watch(ctx, config, func(changed map[string]string, removed []string) {
+			results <- diff{changed: changed, removed: removed}
+		})
+	}()
+
+	// Give the watcher time to install before mutating the file.
+	time.Sleep(100 * time.Millisecond)
+
+	if err := os.WriteFile(envPath, []byte("WATCH_VAR=updated"), 0644); err != nil {
+		t.Fatalf("Failed to update .env file: %v", err)
+	}
+
+	select {
+	case d := <-results:
+		if d.changed["WATCH_VAR"] != "updated" {
+			t.Errorf("Expected WATCH_VAR=updated in the reported diff, got %v", d.changed)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for Watch to report the change")
+	}
+
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Fatalf("Watch returned an error: %v", err)
+	}
+}